@@ -0,0 +1,81 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ──────────────────────────────────────────────────────────────
+// Admin endpoints
+// ──────────────────────────────────────────────────────────────
+
+// handleAdminReprovide triggers an immediate sweep that re-announces every
+// key this node has provided, rather than waiting for the next scheduled
+// rebroadcast.
+func (s *Server) handleAdminReprovide(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	status := s.node.Reprovide(ctx)
+
+	writeJSON(w, status)
+}
+
+// handleAdminReprovideStatus reports the outcome of the most recent
+// reprovide sweep, whether triggered via /admin/reprovide or the
+// background rebroadcast loop.
+func (s *Server) handleAdminReprovideStatus(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.node.LastReprovideStatus())
+}
+
+// handleAdminCompact triggers an immediate BadgerDB value-log garbage
+// collection pass on the node's datastore.
+func (s *Server) handleAdminCompact(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), 60*time.Second)
+	defer cancel()
+
+	if err := s.node.Compact(ctx); err != nil {
+		http.Error(w, fmt.Sprintf("compact failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminTrustedPeers reports the peers currently allowed to publish
+// to the default GossipSub topics.
+func (s *Server) handleAdminTrustedPeers(w http.ResponseWriter, r *http.Request) {
+	peers := s.node.TrustedPeers()
+	result := make([]string, len(peers))
+	for i, p := range peers {
+		result[i] = p.String()
+	}
+	writeJSON(w, result)
+}
+
+// handleAdminAddTrustedPeer adds a peer to the default topics' allow-list.
+func (s *Server) handleAdminAddTrustedPeer(w http.ResponseWriter, r *http.Request) {
+	pid, err := peer.Decode(r.PathValue("peerID"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid peer id: %v", err), http.StatusBadRequest)
+		return
+	}
+	s.node.AddTrustedPeer(pid)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handleAdminRemoveTrustedPeer removes a peer from the default topics'
+// allow-list.
+func (s *Server) handleAdminRemoveTrustedPeer(w http.ResponseWriter, r *http.Request) {
+	pid, err := peer.Decode(r.PathValue("peerID"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid peer id: %v", err), http.StatusBadRequest)
+		return
+	}
+	s.node.RemoveTrustedPeer(pid)
+	w.WriteHeader(http.StatusNoContent)
+}
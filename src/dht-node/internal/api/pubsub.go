@@ -0,0 +1,188 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const sseMediaType = "text/event-stream"
+
+// pubsubHeartbeatInterval is how often a heartbeat is sent on an idle
+// subscription to keep the HTTP connection (and any intermediaries) alive.
+const pubsubHeartbeatInterval = 15 * time.Second
+
+func (s *Server) handlePubSubPublish(w http.ResponseWriter, r *http.Request) {
+	topicName := r.PathValue("topic")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "read body failed", http.StatusBadRequest)
+		return
+	}
+
+	topic, err := s.node.JoinTopic(topicName)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("join topic failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+
+	if err := topic.Publish(ctx, body); err != nil {
+		http.Error(w, fmt.Sprintf("publish failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PubSubMessage is a single GossipSub message delivered over a streaming
+// subscription.
+type PubSubMessage struct {
+	From string `json:"from"`
+	Data []byte `json:"data"`
+	Seq  []byte `json:"seq,omitempty"`
+}
+
+// handlePubSubSubscribe streams messages for a topic for the lifetime of
+// the HTTP connection, as NDJSON (default) or SSE (Accept: text/event-stream).
+// Heartbeats keep the connection alive across periods with no traffic.
+func (s *Server) handlePubSubSubscribe(w http.ResponseWriter, r *http.Request) {
+	topicName := r.PathValue("topic")
+
+	sub, err := s.node.Subscribe(topicName)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("subscribe failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	defer sub.Cancel()
+
+	sse := strings.Contains(r.Header.Get("Accept"), sseMediaType)
+	if sse {
+		w.Header().Set("Content-Type", sseMediaType)
+	} else {
+		w.Header().Set("Content-Type", ndjsonMediaType)
+	}
+	// Clear the server-wide WriteTimeout for this response, which would
+	// otherwise cut the stream off after a fixed time regardless of traffic.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	ctx := r.Context()
+
+	msgCh := make(chan PubSubMessage)
+	go func() {
+		defer close(msgCh)
+		for {
+			msg, err := sub.Next(ctx)
+			if err != nil {
+				return
+			}
+			select {
+			case msgCh <- PubSubMessage{From: msg.GetFrom().String(), Data: msg.GetData(), Seq: msg.GetSeqno()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(pubsubHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-msgCh:
+			if !ok {
+				return
+			}
+			writeStreamEvent(w, sse, msg)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		case <-ticker.C:
+			writeStreamHeartbeat(w, sse)
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func writeStreamEvent(w io.Writer, sse bool, msg PubSubMessage) {
+	if sse {
+		fmt.Fprint(w, "event: message\ndata: ")
+		json.NewEncoder(w).Encode(msg)
+		fmt.Fprint(w, "\n")
+		return
+	}
+	json.NewEncoder(w).Encode(msg)
+}
+
+func writeStreamHeartbeat(w io.Writer, sse bool) {
+	if sse {
+		fmt.Fprint(w, ": heartbeat\n\n")
+		return
+	}
+	fmt.Fprint(w, "\n")
+}
+
+func (s *Server) handlePubSubTopics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.node.Topics())
+}
+
+func (s *Server) handlePubSubTopicPeers(w http.ResponseWriter, r *http.Request) {
+	topicName := r.PathValue("topic")
+
+	peers := s.node.TopicPeers(topicName)
+	result := make([]string, len(peers))
+	for i, p := range peers {
+		result[i] = p.String()
+	}
+	writeJSON(w, result)
+}
+
+// registerValidatorRequest is the body of POST /pubsub/validator/{topic}.
+// AllowedPeers is a signed-sender allowlist: messages from any other peer
+// are rejected before reaching subscribers.
+type registerValidatorRequest struct {
+	AllowedPeers []string `json:"allowedPeers"`
+}
+
+func (s *Server) handlePubSubRegisterValidator(w http.ResponseWriter, r *http.Request) {
+	topicName := r.PathValue("topic")
+
+	var req registerValidatorRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, 1<<16)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	allowed := make([]peer.ID, 0, len(req.AllowedPeers))
+	for _, idStr := range req.AllowedPeers {
+		pid, err := peer.Decode(idStr)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid peer id %q: %v", idStr, err), http.StatusBadRequest)
+			return
+		}
+		allowed = append(allowed, pid)
+	}
+
+	if err := s.node.RegisterAllowListValidator(topicName, allowed); err != nil {
+		http.Error(w, fmt.Sprintf("register validator failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
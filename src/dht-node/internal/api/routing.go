@@ -0,0 +1,157 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ──────────────────────────────────────────────────────────────
+// Delegated Routing V1 HTTP API (IPIP-337 / IPIP-417)
+//
+// This lets a resource-constrained node agent query the DHT VM's
+// Kademlia routing table over plain HTTP instead of joining libp2p
+// directly, turning the VM into a shareable delegated-routing endpoint.
+// ──────────────────────────────────────────────────────────────
+
+const ndjsonMediaType = "application/x-ndjson"
+
+// RoutingProvider is a single provider record in the Delegated Routing
+// V1 response schema.
+type RoutingProvider struct {
+	Schema    string   `json:"Schema"`
+	ID        string   `json:"ID"`
+	Addrs     []string `json:"Addrs"`
+	Protocols []string `json:"Protocols"`
+}
+
+// RoutingProvidersResponse is the batched JSON envelope for
+// GET /routing/v1/providers/{cid}.
+type RoutingProvidersResponse struct {
+	Providers []RoutingProvider `json:"Providers"`
+}
+
+func (s *Server) handleRoutingProviders(w http.ResponseWriter, r *http.Request) {
+	cidStr := r.PathValue("cid")
+
+	c, err := cid.Decode(cidStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid cid: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	providers := s.node.DHT.FindProvidersAsync(ctx, c, 20)
+
+	if acceptsNDJSON(r) {
+		w.Header().Set("Content-Type", ndjsonMediaType)
+		enc := json.NewEncoder(w)
+		flusher, _ := w.(http.Flusher)
+		for p := range providers {
+			if err := enc.Encode(RoutingProvidersResponse{Providers: []RoutingProvider{addrInfoToProvider(p)}}); err != nil {
+				return
+			}
+			if flusher != nil {
+				flusher.Flush()
+			}
+		}
+		return
+	}
+
+	resp := RoutingProvidersResponse{Providers: make([]RoutingProvider, 0)}
+	for p := range providers {
+		resp.Providers = append(resp.Providers, addrInfoToProvider(p))
+	}
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleRoutingPeer(w http.ResponseWriter, r *http.Request) {
+	peerIDStr := r.PathValue("peer-id")
+
+	pid, err := peer.Decode(peerIDStr)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid peer id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	addrInfo, err := s.node.DHT.FindPeer(ctx, pid)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("find peer failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	resp := RoutingProvidersResponse{Providers: []RoutingProvider{addrInfoToProvider(addrInfo)}}
+
+	if acceptsNDJSON(r) {
+		w.Header().Set("Content-Type", ndjsonMediaType)
+		json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	writeJSON(w, resp)
+}
+
+func (s *Server) handleRoutingIPNSGet(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	value, err := s.node.DHT.GetValue(ctx, "/ipns/"+name)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("ipns get failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.ipfs.ipns-record")
+	w.Write(value)
+}
+
+func (s *Server) handleRoutingIPNSPut(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "read body failed", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := s.node.DHT.PutValue(ctx, "/ipns/"+name, body); err != nil {
+		http.Error(w, fmt.Sprintf("ipns put failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func acceptsNDJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), ndjsonMediaType)
+}
+
+func addrInfoToProvider(p peer.AddrInfo) RoutingProvider {
+	addrs := make([]string, len(p.Addrs))
+	for i, a := range p.Addrs {
+		addrs[i] = a.String()
+	}
+	return RoutingProvider{
+		Schema:    "peer",
+		ID:        p.ID.String(),
+		Addrs:     addrs,
+		Protocols: []string{"transport-bitswap"},
+	}
+}
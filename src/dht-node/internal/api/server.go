@@ -35,13 +35,35 @@ func NewServer(node *dhtnode.Node, port int) *Server {
 	s.mux.HandleFunc("GET /health", s.handleHealth)
 	s.mux.HandleFunc("GET /peers", s.handlePeers)
 	s.mux.HandleFunc("GET /peer/{peerID}", s.handlePeer)
+	s.mux.HandleFunc("GET /metrics/peerscores", s.handlePeerScores)
+	s.mux.HandleFunc("GET /metrics/discovery", s.handleDiscoveryMetrics)
 
 	s.mux.HandleFunc("GET /dht/get/{key...}", s.handleDHTGet)
 	s.mux.HandleFunc("PUT /dht/put/{key...}", s.handleDHTPut)
 	s.mux.HandleFunc("GET /dht/providers/{key...}", s.handleDHTFindProviders)
 	s.mux.HandleFunc("POST /dht/provide/{key...}", s.handleDHTProvide)
 
+	s.mux.HandleFunc("PUT /dht/signed/{namespace}/{key...}", s.handleDHTSignedPut)
+	s.mux.HandleFunc("GET /dht/signed/{namespace}/{owner}/{key...}", s.handleDHTSignedGet)
+
 	s.mux.HandleFunc("POST /pubsub/publish/{topic...}", s.handlePubSubPublish)
+	s.mux.HandleFunc("GET /pubsub/subscribe/{topic...}", s.handlePubSubSubscribe)
+	s.mux.HandleFunc("GET /pubsub/topics", s.handlePubSubTopics)
+	s.mux.HandleFunc("GET /pubsub/peers/{topic...}", s.handlePubSubTopicPeers)
+	s.mux.HandleFunc("POST /pubsub/validator/{topic...}", s.handlePubSubRegisterValidator)
+
+	s.mux.HandleFunc("GET /routing/v1/providers/{cid}", s.handleRoutingProviders)
+	s.mux.HandleFunc("GET /routing/v1/peers/{peer-id}", s.handleRoutingPeer)
+	s.mux.HandleFunc("GET /routing/v1/ipns/{name}", s.handleRoutingIPNSGet)
+	s.mux.HandleFunc("PUT /routing/v1/ipns/{name}", s.handleRoutingIPNSPut)
+
+	s.mux.HandleFunc("POST /admin/reprovide", s.handleAdminReprovide)
+	s.mux.HandleFunc("GET /admin/reprovide/status", s.handleAdminReprovideStatus)
+	s.mux.HandleFunc("POST /admin/compact", s.handleAdminCompact)
+
+	s.mux.HandleFunc("GET /admin/trusted-peers", s.handleAdminTrustedPeers)
+	s.mux.HandleFunc("POST /admin/trusted-peers/{peerID}", s.handleAdminAddTrustedPeer)
+	s.mux.HandleFunc("DELETE /admin/trusted-peers/{peerID}", s.handleAdminRemoveTrustedPeer)
 
 	s.server = &http.Server{
 		Addr:         fmt.Sprintf("127.0.0.1:%d", port),
@@ -69,23 +91,42 @@ func (s *Server) Shutdown(ctx context.Context) error {
 // ──────────────────────────────────────────────────────────────
 
 type HealthResponse struct {
-	PeerID           string `json:"peerId"`
-	ConnectedPeers   int    `json:"connectedPeers"`
-	RoutingTableSize int    `json:"routingTableSize"`
-	Status           string `json:"status"`
+	PeerID              string   `json:"peerId"`
+	ConnectedPeers      int      `json:"connectedPeers"`
+	RoutingTableSize    int      `json:"routingTableSize"`
+	RoutingTableSizeWAN int      `json:"routingTableSizeWan"`
+	RoutingTableSizeLAN int      `json:"routingTableSizeLan"`
+	Mode                string   `json:"mode"`
+	Transports          []string `json:"transports"`
+	StoreBytes          uint64   `json:"storeBytes,omitempty"`
+	Status              string   `json:"status"`
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	wanSize, lanSize := s.node.RoutingTableSizes()
+
 	status := "active"
-	if s.node.ConnectedPeers() == 0 && s.node.RoutingTableSize() == 0 {
+	if s.node.ConnectedPeers() == 0 && wanSize+lanSize == 0 {
 		status = "initializing"
 	}
 
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	storeBytes, err := s.node.StoreSize(ctx)
+	cancel()
+	if err != nil {
+		s.logger.Warn("store size unavailable", "error", err)
+	}
+
 	writeJSON(w, HealthResponse{
-		PeerID:           s.node.PeerID(),
-		ConnectedPeers:   s.node.ConnectedPeers(),
-		RoutingTableSize: s.node.RoutingTableSize(),
-		Status:           status,
+		PeerID:              s.node.PeerID(),
+		ConnectedPeers:      s.node.ConnectedPeers(),
+		RoutingTableSize:    wanSize + lanSize,
+		RoutingTableSizeWAN: wanSize,
+		RoutingTableSizeLAN: lanSize,
+		Mode:                s.node.Config.Mode,
+		Transports:          s.node.Transports(),
+		StoreBytes:          storeBytes,
+		Status:              status,
 	})
 }
 
@@ -129,6 +170,14 @@ func (s *Server) handlePeer(w http.ResponseWriter, r *http.Request) {
 	http.Error(w, "peer not found", http.StatusNotFound)
 }
 
+func (s *Server) handlePeerScores(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.node.PeerScores())
+}
+
+func (s *Server) handleDiscoveryMetrics(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, s.node.DiscoveryMetrics())
+}
+
 // ──────────────────────────────────────────────────────────────
 // DHT key-value endpoints
 // ──────────────────────────────────────────────────────────────
@@ -136,10 +185,16 @@ func (s *Server) handlePeer(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleDHTGet(w http.ResponseWriter, r *http.Request) {
 	key := "/" + r.PathValue("key")
 
+	rt, err := s.node.RoutingForScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	value, err := s.node.DHT.GetValue(ctx, key)
+	value, err := rt.GetValue(ctx, key)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("DHT get failed: %v", err), http.StatusNotFound)
 		return
@@ -152,6 +207,12 @@ func (s *Server) handleDHTGet(w http.ResponseWriter, r *http.Request) {
 func (s *Server) handleDHTPut(w http.ResponseWriter, r *http.Request) {
 	key := "/" + r.PathValue("key")
 
+	rt, err := s.node.RoutingForScope(r.URL.Query().Get("scope"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20)) // 1MB limit
 	if err != nil {
 		http.Error(w, "read body failed", http.StatusBadRequest)
@@ -161,7 +222,7 @@ func (s *Server) handleDHTPut(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
 	defer cancel()
 
-	if err := s.node.DHT.PutValue(ctx, key, body); err != nil {
+	if err := rt.PutValue(ctx, key, body); err != nil {
 		http.Error(w, fmt.Sprintf("DHT put failed: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -177,10 +238,14 @@ func (s *Server) handleDHTFindProviders(w http.ResponseWriter, r *http.Request)
 
 	c := keyToCID(key)
 
-	providers := s.node.DHT.FindProvidersAsync(ctx, c, 20)
+	providers, err := s.node.FindProviders(ctx, c, r.URL.Query().Get("scope"), 20)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("DHT find providers failed: %v", err), http.StatusInternalServerError)
+		return
+	}
 
-	result := make([]PeerInfo, 0)
-	for p := range providers {
+	result := make([]PeerInfo, 0, len(providers))
+	for _, p := range providers {
 		addrStrs := make([]string, len(p.Addrs))
 		for i, a := range p.Addrs {
 			addrStrs[i] = a.String()
@@ -199,7 +264,7 @@ func (s *Server) handleDHTProvide(w http.ResponseWriter, r *http.Request) {
 
 	c := keyToCID(key)
 
-	if err := s.node.DHT.Provide(ctx, c, true); err != nil {
+	if err := s.node.Provide(ctx, c, r.URL.Query().Get("scope"), true); err != nil {
 		http.Error(w, fmt.Sprintf("DHT provide failed: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -207,36 +272,6 @@ func (s *Server) handleDHTProvide(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
-// ──────────────────────────────────────────────────────────────
-// PubSub endpoint
-// ──────────────────────────────────────────────────────────────
-
-func (s *Server) handlePubSubPublish(w http.ResponseWriter, r *http.Request) {
-	topicName := r.PathValue("topic")
-
-	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
-	if err != nil {
-		http.Error(w, "read body failed", http.StatusBadRequest)
-		return
-	}
-
-	topic, err := s.node.JoinTopic(topicName)
-	if err != nil {
-		http.Error(w, fmt.Sprintf("join topic failed: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	ctx, cancel := context.WithTimeout(r.Context(), 10*time.Second)
-	defer cancel()
-
-	if err := topic.Publish(ctx, body); err != nil {
-		http.Error(w, fmt.Sprintf("publish failed: %v", err), http.StatusInternalServerError)
-		return
-	}
-
-	w.WriteHeader(http.StatusNoContent)
-}
-
 // ──────────────────────────────────────────────────────────────
 // Helpers
 // ──────────────────────────────────────────────────────────────
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// SignedRecordResponse is the body of GET /dht/signed/{namespace}/{owner}/{key}.
+type SignedRecordResponse struct {
+	Value  []byte `json:"value"`
+	Signer string `json:"signer"`
+	Seq    uint64 `json:"seq"`
+}
+
+// handleDHTSignedPut always writes under this node's own peer ID — see
+// Node.PutSigned — so there is no owner path segment to parse here.
+func (s *Server) handleDHTSignedPut(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	key := r.PathValue("key")
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "read body failed", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	if err := s.node.PutSigned(ctx, namespace, key, body, 0); err != nil {
+		http.Error(w, fmt.Sprintf("signed put failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleDHTSignedGet(w http.ResponseWriter, r *http.Request) {
+	namespace := r.PathValue("namespace")
+	key := r.PathValue("key")
+
+	owner, err := peer.Decode(r.PathValue("owner"))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid owner peer id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 15*time.Second)
+	defer cancel()
+
+	value, seq, err := s.node.GetSigned(ctx, namespace, owner, key)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("signed get failed: %v", err), http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, SignedRecordResponse{
+		Value:  value,
+		Signer: owner.String(),
+		Seq:    seq,
+	})
+}
@@ -0,0 +1,53 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+	madns "github.com/multiformats/go-multiaddr-dns"
+)
+
+// DNSSource resolves bootstrap peers from a dnsaddr TXT record set, per the
+// multiaddr dnsaddr spec: TXT records at _dnsaddr.<Domain> each contain a
+// "dnsaddr=<multiaddr>" value, with the ones ending in /p2p/<peerID>
+// identifying bootstrap peers directly.
+type DNSSource struct {
+	Domain   string
+	resolver *madns.Resolver
+}
+
+// NewDNSSource creates a DNSSource that resolves bootstrap peers under
+// domain (e.g. "bootstrap.decloud.example.com").
+func NewDNSSource(domain string) (*DNSSource, error) {
+	resolver, err := madns.NewResolver()
+	if err != nil {
+		return nil, fmt.Errorf("create dns resolver: %w", err)
+	}
+	return &DNSSource{Domain: domain, resolver: resolver}, nil
+}
+
+func (s *DNSSource) Name() string { return "dns:" + s.Domain }
+
+func (s *DNSSource) Peers(ctx context.Context) ([]peer.AddrInfo, error) {
+	dnsaddr, err := multiaddr.NewMultiaddr("/dnsaddr/" + s.Domain)
+	if err != nil {
+		return nil, fmt.Errorf("build dnsaddr multiaddr: %w", err)
+	}
+
+	resolved, err := s.resolver.Resolve(ctx, dnsaddr)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", s.Name(), err)
+	}
+
+	result := make([]peer.AddrInfo, 0, len(resolved))
+	for _, ma := range resolved {
+		pi, err := peer.AddrInfoFromP2pAddr(ma)
+		if err != nil {
+			continue
+		}
+		result = append(result, *pi)
+	}
+	return result, nil
+}
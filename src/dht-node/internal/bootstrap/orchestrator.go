@@ -0,0 +1,31 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// OrchestratorSource resolves bootstrap peers by pulling the current
+// cluster membership from the DeCloud orchestrator's callback endpoint,
+// authenticated with a bearer token (DHT_ORCHESTRATOR_URL /
+// DHT_ORCHESTRATOR_TOKEN). It shares the well-known endpoint's response
+// schema.
+type OrchestratorSource struct {
+	URL    string
+	Token  string
+	Client *http.Client
+}
+
+// NewOrchestratorSource creates an OrchestratorSource for the given callback
+// URL and bearer token.
+func NewOrchestratorSource(url, token string) *OrchestratorSource {
+	return &OrchestratorSource{URL: url, Token: token, Client: http.DefaultClient}
+}
+
+func (s *OrchestratorSource) Name() string { return "orchestrator" }
+
+func (s *OrchestratorSource) Peers(ctx context.Context) ([]peer.AddrInfo, error) {
+	return fetchPeerList(ctx, s.Client, s.URL, s.Token)
+}
@@ -0,0 +1,96 @@
+package bootstrap
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// ConnectFunc dials and registers pi with the local peerstore, mirroring
+// host.Host.Connect. Reconciler calls it for every peer on every tick;
+// connecting to an already-connected peer is a no-op.
+type ConnectFunc func(ctx context.Context, pi peer.AddrInfo) error
+
+// sourceTimeout bounds how long reconcile waits on any single Source before
+// giving up on it for that tick. Sources like WellKnownSource/
+// OrchestratorSource make outbound HTTP calls with no deadline of their own;
+// without this, a slow or firewalled endpoint would block reconcile's
+// wg.Wait() forever and wedge reconciliation for every other source too,
+// since Run calls reconcile synchronously on every tick.
+const sourceTimeout = 10 * time.Second
+
+// Reconciler periodically polls a set of Sources, merges their results by
+// peer ID, and reconnects to the union set. This lets a DHT node recover
+// from dropped bootstrap peers and pick up newly joined ones without a
+// restart.
+type Reconciler struct {
+	sources  []Source
+	interval time.Duration
+	connect  ConnectFunc
+	logger   *slog.Logger
+}
+
+// NewReconciler creates a Reconciler that polls sources every interval.
+func NewReconciler(sources []Source, interval time.Duration, connect ConnectFunc, logger *slog.Logger) *Reconciler {
+	return &Reconciler{sources: sources, interval: interval, connect: connect, logger: logger}
+}
+
+// Run polls and reconciles until ctx is cancelled. It reconciles once
+// immediately before entering the ticker loop, so the node doesn't wait a
+// full interval after startup to pick up dynamic peers.
+func (r *Reconciler) Run(ctx context.Context) {
+	r.reconcile(ctx)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.reconcile(ctx)
+		}
+	}
+}
+
+// reconcile fetches all sources concurrently, merges the results by peer
+// ID, and attempts to connect to every peer in the union set.
+func (r *Reconciler) reconcile(ctx context.Context) {
+	merged := make(map[peer.ID]peer.AddrInfo)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for _, src := range r.sources {
+		wg.Add(1)
+		go func(src Source) {
+			defer wg.Done()
+
+			srcCtx, cancel := context.WithTimeout(ctx, sourceTimeout)
+			defer cancel()
+
+			peers, err := src.Peers(srcCtx)
+			if err != nil {
+				r.logger.Warn("bootstrap source failed", "source", src.Name(), "error", err)
+				return
+			}
+
+			mu.Lock()
+			for _, pi := range peers {
+				merged[pi.ID] = pi
+			}
+			mu.Unlock()
+		}(src)
+	}
+
+	wg.Wait()
+
+	for _, pi := range merged {
+		if err := r.connect(ctx, pi); err != nil {
+			r.logger.Debug("bootstrap reconnect failed", "peer", pi.ID, "error", err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+// Package bootstrap resolves the set of bootstrap peers a DHT node should
+// stay connected to, from multiple pluggable sources, and continuously
+// reconciles connections against the merged result so the fleet self-heals
+// as VMs come and go without a node restart.
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Source resolves a set of bootstrap peer addresses. Reconciler polls every
+// registered Source on each tick and merges the results by peer ID.
+type Source interface {
+	Name() string
+	Peers(ctx context.Context) ([]peer.AddrInfo, error)
+}
@@ -0,0 +1,38 @@
+package bootstrap
+
+import (
+	"context"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+)
+
+// StaticSource resolves the fixed list of bootstrap peer multiaddrs
+// configured once at startup (DHT_BOOTSTRAP_PEERS). Invalid addresses are
+// skipped silently since they are already logged once at node startup.
+type StaticSource struct {
+	addrs []string
+}
+
+// NewStaticSource creates a StaticSource over the given multiaddr strings.
+func NewStaticSource(addrs []string) *StaticSource {
+	return &StaticSource{addrs: addrs}
+}
+
+func (s *StaticSource) Name() string { return "static" }
+
+func (s *StaticSource) Peers(ctx context.Context) ([]peer.AddrInfo, error) {
+	result := make([]peer.AddrInfo, 0, len(s.addrs))
+	for _, addr := range s.addrs {
+		ma, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			continue
+		}
+		pi, err := peer.AddrInfoFromP2pAddr(ma)
+		if err != nil {
+			continue
+		}
+		result = append(result, *pi)
+	}
+	return result, nil
+}
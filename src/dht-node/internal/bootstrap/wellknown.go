@@ -0,0 +1,77 @@
+package bootstrap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	multiaddr "github.com/multiformats/go-multiaddr"
+)
+
+// peerListResponse is the JSON schema shared by the well-known bootstrap
+// URL and the orchestrator callback: a flat list of peer multiaddrs.
+type peerListResponse struct {
+	Peers []string `json:"peers"`
+}
+
+// WellKnownSource resolves bootstrap peers from an HTTPS JSON endpoint
+// returning {"peers": ["<multiaddr>", ...]}, polled on the Reconciler's
+// interval (configured via DHT_BOOTSTRAP_URL).
+type WellKnownSource struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewWellKnownSource creates a WellKnownSource for the given endpoint.
+func NewWellKnownSource(url string) *WellKnownSource {
+	return &WellKnownSource{URL: url, Client: http.DefaultClient}
+}
+
+func (s *WellKnownSource) Name() string { return "well-known:" + s.URL }
+
+func (s *WellKnownSource) Peers(ctx context.Context) ([]peer.AddrInfo, error) {
+	return fetchPeerList(ctx, s.Client, s.URL, "")
+}
+
+// fetchPeerList issues a GET to url (bearer-authenticated when token is
+// non-empty) and parses a peerListResponse into peer.AddrInfo values.
+func fetchPeerList(ctx context.Context, client *http.Client, url, token string) ([]peer.AddrInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("build request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	var parsed peerListResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("decode response from %s: %w", url, err)
+	}
+
+	result := make([]peer.AddrInfo, 0, len(parsed.Peers))
+	for _, addr := range parsed.Peers {
+		ma, err := multiaddr.NewMultiaddr(addr)
+		if err != nil {
+			continue
+		}
+		pi, err := peer.AddrInfoFromP2pAddr(ma)
+		if err != nil {
+			continue
+		}
+		result = append(result, *pi)
+	}
+	return result, nil
+}
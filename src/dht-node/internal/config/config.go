@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all DHT node configuration, populated from environment variables
@@ -31,15 +32,119 @@ type Config struct {
 
 	// DataDir is where the DHT node stores persistent state (peer ID key, datastore)
 	DataDir string
+
+	// Mode selects which Kademlia routing table(s) the node runs:
+	// "wan" (default, single public-facing DHT), "lan" (single DHT
+	// restricted to private-IP peers), or "dual" (both, multiplexed).
+	Mode string
+
+	// WANMode and LANMode select whether the corresponding Kademlia DHT
+	// (active per Mode) runs as "server" (responds to queries, the
+	// default) or "client" (queries only, doesn't join the routing
+	// table) — useful for e.g. a LAN-only deployment that wants to
+	// consult the WAN DHT without advertising itself on it.
+	WANMode string
+	LANMode string
+
+	// ConnManagerLow/High are the libp2p connection manager's low/high
+	// watermarks: once connection count exceeds High, the manager trims
+	// down towards Low.
+	ConnManagerLow  int
+	ConnManagerHigh int
+
+	// ConnManagerGrace is how long a newly-opened connection is protected
+	// from trimming.
+	ConnManagerGrace time.Duration
+
+	// ResourceManagerLimitsFile optionally points to a JSON file holding a
+	// libp2p rcmgr.PartialLimitConfig, overlaid on rcmgr's auto-scaled
+	// default limits. If empty, the auto-scaled defaults are used as-is.
+	ResourceManagerLimitsFile string
+
+	// PeerScoreParamsFile optionally points to a JSON file overriding the
+	// GossipSub peer scoring defaults (per-topic P1-P4 weights and the
+	// global gossip/publish/graylist thresholds). If empty, the built-in
+	// defaults in dht/score.go are used as-is.
+	PeerScoreParamsFile string
+
+	// ProvideValidity is how long a provider record this node publishes
+	// stays valid on the DHT before it must be re-announced.
+	ProvideValidity time.Duration
+
+	// RebroadcastInterval is how often the background reprovide loop
+	// re-announces this node's provider records, to keep them alive
+	// well inside ProvideValidity.
+	RebroadcastInterval time.Duration
+
+	// BootstrapDNSDomain, if set, adds a dnsaddr TXT lookup
+	// (_dnsaddr.<domain>) as a bootstrap peer source.
+	BootstrapDNSDomain string
+
+	// BootstrapURL, if set, adds an HTTPS well-known JSON endpoint
+	// ({"peers":[multiaddrs...]}) as a bootstrap peer source.
+	BootstrapURL string
+
+	// OrchestratorURL and OrchestratorToken, if set, add a bearer-
+	// authenticated pull of the orchestrator's current cluster membership
+	// as a bootstrap peer source.
+	OrchestratorURL   string
+	OrchestratorToken string
+
+	// BootstrapReconcileInterval is how often the dynamic bootstrap
+	// sources (DNS, well-known URL, orchestrator callback) are re-polled
+	// and dropped bootstrap connections are retried.
+	BootstrapReconcileInterval time.Duration
+
+	// Rendezvous is the default rendezvous namespace this node advertises
+	// itself under and searches for peers in, via the discovery subsystem.
+	Rendezvous string
+
+	// RendezvousTTL is how long an advertisement under a rendezvous
+	// namespace is valid before it must be refreshed.
+	RendezvousTTL time.Duration
+
+	// AuthorizedPeers, if set, restricts the default GossipSub topics
+	// (decloud/health, decloud/events, decloud/blocks) to messages signed
+	// by one of these peer IDs. If empty, any peer may publish to them;
+	// the allow-list can still be changed at runtime via Node.AddTrustedPeer
+	// / Node.RemoveTrustedPeer.
+	AuthorizedPeers []string
 }
 
+// DHT modes accepted by the Mode field.
+const (
+	ModeWAN  = "wan"
+	ModeLAN  = "lan"
+	ModeDual = "dual"
+)
+
+// Per-network DHT modes accepted by the WANMode/LANMode fields.
+const (
+	DHTModeServer = "server"
+	DHTModeClient = "client"
+)
+
 // LoadFromEnv reads configuration from environment variables.
 // These are set by the orchestrator's cloud-init via VM labels.
 func LoadFromEnv() (*Config, error) {
 	cfg := &Config{
-		ListenPort: 4001,
-		APIPort:    5080,
-		DataDir:    "/var/lib/decloud-dht",
+		ListenPort:       4001,
+		APIPort:          5080,
+		DataDir:          "/var/lib/decloud-dht",
+		Mode:             ModeWAN,
+		WANMode:          DHTModeServer,
+		LANMode:          DHTModeServer,
+		ConnManagerLow:   100,
+		ConnManagerHigh:  400,
+		ConnManagerGrace: 30 * time.Second,
+
+		ProvideValidity:     48 * time.Hour,
+		RebroadcastInterval: 12 * time.Hour,
+
+		BootstrapReconcileInterval: 2 * time.Minute,
+
+		Rendezvous:    "decloud/v1",
+		RendezvousTTL: time.Hour,
 	}
 
 	if port := os.Getenv("DHT_LISTEN_PORT"); port != "" {
@@ -79,5 +184,109 @@ func LoadFromEnv() (*Config, error) {
 		cfg.DataDir = dir
 	}
 
+	if mode := os.Getenv("DHT_MODE"); mode != "" {
+		switch mode {
+		case ModeWAN, ModeLAN, ModeDual:
+			cfg.Mode = mode
+		default:
+			return nil, fmt.Errorf("invalid DHT_MODE %q: must be %q, %q, or %q", mode, ModeWAN, ModeLAN, ModeDual)
+		}
+	}
+
+	if mode := os.Getenv("DHT_WAN_MODE"); mode != "" {
+		switch mode {
+		case DHTModeServer, DHTModeClient:
+			cfg.WANMode = mode
+		default:
+			return nil, fmt.Errorf("invalid DHT_WAN_MODE %q: must be %q or %q", mode, DHTModeServer, DHTModeClient)
+		}
+	}
+
+	if mode := os.Getenv("DHT_LAN_MODE"); mode != "" {
+		switch mode {
+		case DHTModeServer, DHTModeClient:
+			cfg.LANMode = mode
+		default:
+			return nil, fmt.Errorf("invalid DHT_LAN_MODE %q: must be %q or %q", mode, DHTModeServer, DHTModeClient)
+		}
+	}
+
+	if v := os.Getenv("DHT_CONNMGR_LOW"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_CONNMGR_LOW: %w", err)
+		}
+		cfg.ConnManagerLow = n
+	}
+
+	if v := os.Getenv("DHT_CONNMGR_HIGH"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_CONNMGR_HIGH: %w", err)
+		}
+		cfg.ConnManagerHigh = n
+	}
+
+	if v := os.Getenv("DHT_CONNMGR_GRACE_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_CONNMGR_GRACE_SECONDS: %w", err)
+		}
+		cfg.ConnManagerGrace = time.Duration(n) * time.Second
+	}
+
+	cfg.ResourceManagerLimitsFile = os.Getenv("DHT_RCMGR_LIMITS_FILE")
+	cfg.PeerScoreParamsFile = os.Getenv("DHT_PEERSCORE_PARAMS_FILE")
+
+	if v := os.Getenv("DHT_PROVIDE_VALIDITY_HOURS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_PROVIDE_VALIDITY_HOURS: %w", err)
+		}
+		cfg.ProvideValidity = time.Duration(n) * time.Hour
+	}
+
+	if v := os.Getenv("DHT_REBROADCAST_INTERVAL_HOURS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_REBROADCAST_INTERVAL_HOURS: %w", err)
+		}
+		cfg.RebroadcastInterval = time.Duration(n) * time.Hour
+	}
+
+	cfg.BootstrapDNSDomain = os.Getenv("DHT_BOOTSTRAP_DNS_DOMAIN")
+	cfg.BootstrapURL = os.Getenv("DHT_BOOTSTRAP_URL")
+	cfg.OrchestratorURL = os.Getenv("DHT_ORCHESTRATOR_URL")
+	cfg.OrchestratorToken = os.Getenv("DHT_ORCHESTRATOR_TOKEN")
+
+	if v := os.Getenv("DHT_BOOTSTRAP_RECONCILE_SECONDS"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_BOOTSTRAP_RECONCILE_SECONDS: %w", err)
+		}
+		cfg.BootstrapReconcileInterval = time.Duration(n) * time.Second
+	}
+
+	if v := os.Getenv("DHT_RENDEZVOUS"); v != "" {
+		cfg.Rendezvous = v
+	}
+
+	if v := os.Getenv("DHT_RENDEZVOUS_TTL_MINUTES"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid DHT_RENDEZVOUS_TTL_MINUTES: %w", err)
+		}
+		cfg.RendezvousTTL = time.Duration(n) * time.Minute
+	}
+
+	if peers := os.Getenv("DHT_AUTHORIZED_PEERS"); peers != "" {
+		for _, p := range strings.Split(peers, ",") {
+			p = strings.TrimSpace(p)
+			if p != "" {
+				cfg.AuthorizedPeers = append(cfg.AuthorizedPeers, p)
+			}
+		}
+	}
+
 	return cfg, nil
 }
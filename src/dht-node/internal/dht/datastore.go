@@ -0,0 +1,53 @@
+package dht
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	ds "github.com/ipfs/go-datastore"
+	badger "github.com/ipfs/go-ds-badger2"
+)
+
+// newDatastore opens the BadgerDB-backed datastore used for the Kademlia
+// DHT's value records, provider records, and the peerstore. Persisting these
+// means a node restart keeps its stored records and known peer addresses
+// instead of relearning them from the network. The Kademlia routing tables
+// themselves are held in memory and are not stored here.
+func newDatastore(dataDir string) (ds.Batching, error) {
+	dsDir := filepath.Join(dataDir, "datastore")
+
+	store, err := badger.NewDatastore(dsDir, nil)
+	if err != nil {
+		return nil, fmt.Errorf("open badger datastore at %s: %w", dsDir, err)
+	}
+
+	return store, nil
+}
+
+// compactableStore is satisfied by the BadgerDB-backed datastore, exposing
+// maintenance operations ds.Batching itself doesn't.
+type compactableStore interface {
+	CollectGarbage(ctx context.Context) error
+	DiskUsage(ctx context.Context) (uint64, error)
+}
+
+// Compact runs BadgerDB's value-log garbage collection on the node's
+// datastore, reclaiming space left behind by overwritten or expired
+// records. Safe to call while the node is running.
+func (n *Node) Compact(ctx context.Context) error {
+	cs, ok := n.store.(compactableStore)
+	if !ok {
+		return fmt.Errorf("datastore does not support compaction")
+	}
+	return cs.CollectGarbage(ctx)
+}
+
+// StoreSize returns the on-disk size of the persistent datastore, in bytes.
+func (n *Node) StoreSize(ctx context.Context) (uint64, error) {
+	cs, ok := n.store.(compactableStore)
+	if !ok {
+		return 0, fmt.Errorf("datastore does not report disk usage")
+	}
+	return cs.DiskUsage(ctx)
+}
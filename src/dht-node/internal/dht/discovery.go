@@ -0,0 +1,25 @@
+package dht
+
+import "github.com/decloud/dht-node/internal/discovery"
+
+// Rendezvous starts advertising and discovering peers under the given
+// rendezvous namespace, in addition to the node's default one. Use this to
+// join per-tenant or per-region cohorts at runtime.
+func (n *Node) Rendezvous(name string) {
+	n.discovery.Rendezvous(name)
+}
+
+// StopRendezvous stops advertising and discovering peers under name.
+func (n *Node) StopRendezvous(name string) {
+	n.discovery.StopRendezvous(name)
+}
+
+// RendezvousNamespaces returns the currently active rendezvous namespaces.
+func (n *Node) RendezvousNamespaces() []string {
+	return n.discovery.Namespaces()
+}
+
+// DiscoveryMetrics returns a snapshot of rendezvous discovery activity.
+func (n *Node) DiscoveryMetrics() discovery.Metrics {
+	return n.discovery.Metrics()
+}
@@ -7,30 +7,88 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/decloud/dht-node/internal/bootstrap"
 	"github.com/decloud/dht-node/internal/config"
+	"github.com/decloud/dht-node/internal/dht/records"
+	"github.com/decloud/dht-node/internal/discovery"
+	ds "github.com/ipfs/go-datastore"
+	"github.com/ipfs/go-datastore/namespace"
 	"github.com/libp2p/go-libp2p"
 	dht "github.com/libp2p/go-libp2p-kad-dht"
+	"github.com/libp2p/go-libp2p-kad-dht/dual"
+	"github.com/libp2p/go-libp2p-kad-dht/providers"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/libp2p/go-libp2p/core/routing"
 	"github.com/libp2p/go-libp2p/p2p/discovery/mdns"
+	"github.com/libp2p/go-libp2p/p2p/host/peerstore/pstoreds"
+	"github.com/libp2p/go-libp2p/p2p/net/connmgr"
 	multiaddr "github.com/multiformats/go-multiaddr"
 )
 
 // Node is the DeCloud DHT node wrapping libp2p, Kademlia, and GossipSub.
 type Node struct {
-	Host   host.Host
-	DHT    *dht.IpfsDHT
+	Host host.Host
+	// DHT is the routing backend used by default (scope "any"): the single
+	// Kademlia DHT in "wan"/"lan" mode, or the multiplexed dual.DHT in
+	// "dual" mode. See RoutingForScope for per-network access.
+	DHT    routing.Routing
 	PubSub *pubsub.PubSub
 	Config *config.Config
 
+	// wan and lan are the concrete per-network routing tables backing DHT.
+	// Only the ones relevant to Config.Mode are non-nil.
+	wan *dht.IpfsDHT
+	lan *dht.IpfsDHT
+
+	// identity is this node's persistent libp2p key, reused to sign
+	// records it writes into the DHT (see PutSigned).
+	identity crypto.PrivKey
+
+	// peerScores is the most recent GossipSub peer score snapshot,
+	// refreshed periodically by recordPeerScores (see PeerScores).
+	peerScores map[peer.ID]float64
+
+	// store is the persistent datastore backing the DHT value records,
+	// provider records and peerstore (see newDatastore).
+	store ds.Batching
+
+	// providedKeys tracks keys this node has announced itself as a
+	// provider for, so Reprovide can re-announce them before their DHT
+	// provider records expire.
+	providedKeys  map[string]providedKey
+	lastReprovide ReprovideStatus
+
+	// discovery advertises this node and finds peers under one or more
+	// rendezvous namespaces (see internal/discovery).
+	discovery *discovery.Discovery
+
 	topics map[string]*pubsub.Topic
+
+	// trustedPeers is the allow-list the default topic validators enforce
+	// (see RegisterAllowListValidator and AddTrustedPeer/RemoveTrustedPeer).
+	// An empty set means no restriction.
+	trustedPeers map[peer.ID]struct{}
+
 	mu     sync.RWMutex
 	logger *slog.Logger
+
+	// stopBootstrap cancels the background bootstrap reconciliation loop
+	// (see internal/bootstrap).
+	stopBootstrap context.CancelFunc
+
+	// stopReprovide cancels the background re-provider loop (runReprovideLoop).
+	stopReprovide context.CancelFunc
 }
 
+// signedRecordNamespace is the DHT key namespace reserved for
+// ed25519-authenticated envelopes (see internal/dht/records).
+const signedRecordNamespace = "signed"
+
 // DeCloud GossipSub topic names
 const (
 	TopicHealth = "decloud/health"
@@ -48,50 +106,100 @@ func New(ctx context.Context, cfg *config.Config) (*Node, error) {
 		return nil, fmt.Errorf("identity key: %w", err)
 	}
 
-	// Build libp2p listen address
-	listenAddr := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.ListenPort)
+	// Build libp2p listen addresses: TCP and QUIC on the same port number,
+	// so a single DHT_LISTEN_PORT covers both transports.
+	listenAddrTCP := fmt.Sprintf("/ip4/0.0.0.0/tcp/%d", cfg.ListenPort)
+	listenAddrQUIC := fmt.Sprintf("/ip4/0.0.0.0/udp/%d/quic-v1", cfg.ListenPort)
+
+	// Build external addresses for advertisement (so other peers can find
+	// us over either transport).
+	externalAddrTCP := fmt.Sprintf("/ip4/%s/tcp/%d", cfg.AdvertiseIP, cfg.ListenPort)
+	externalAddrQUIC := fmt.Sprintf("/ip4/%s/udp/%d/quic-v1", cfg.AdvertiseIP, cfg.ListenPort)
+	extMATCP, err := multiaddr.NewMultiaddr(externalAddrTCP)
+	if err != nil {
+		return nil, fmt.Errorf("parse external TCP multiaddr: %w", err)
+	}
+	extMAQUIC, err := multiaddr.NewMultiaddr(externalAddrQUIC)
+	if err != nil {
+		return nil, fmt.Errorf("parse external QUIC multiaddr: %w", err)
+	}
+	extMAs := []multiaddr.Multiaddr{extMATCP, extMAQUIC}
+
+	cm, err := connmgr.NewConnManager(cfg.ConnManagerLow, cfg.ConnManagerHigh, connmgr.WithGracePeriod(cfg.ConnManagerGrace))
+	if err != nil {
+		return nil, fmt.Errorf("create connection manager: %w", err)
+	}
+
+	rm, err := newResourceManager(cfg.ResourceManagerLimitsFile)
+	if err != nil {
+		return nil, fmt.Errorf("create resource manager: %w", err)
+	}
+
+	store, err := newDatastore(cfg.DataDir)
+	if err != nil {
+		return nil, fmt.Errorf("open datastore: %w", err)
+	}
 
-	// Build external address for advertisement (so other peers can find us)
-	externalAddr := fmt.Sprintf("/ip4/%s/tcp/%d", cfg.AdvertiseIP, cfg.ListenPort)
-	extMA, err := multiaddr.NewMultiaddr(externalAddr)
+	// Back the peerstore with the same BadgerDB, in its own namespace, so
+	// known peer addresses/keys survive a restart instead of requiring a
+	// full bootstrap/discovery sweep to rebuild.
+	pstore, err := pstoreds.NewPeerstore(ctx, namespace.Wrap(store, ds.NewKey("/peerstore")), pstoreds.DefaultOpts())
 	if err != nil {
-		return nil, fmt.Errorf("parse external multiaddr: %w", err)
+		store.Close()
+		return nil, fmt.Errorf("create peerstore: %w", err)
 	}
 
-	// Create libp2p host
+	// ProvideValidity is a package-level knob in go-libp2p-kad-dht; set it
+	// once up front so every DHT this node creates expires provider
+	// records on the same schedule.
+	providers.ProvideValidity = cfg.ProvideValidity
+
+	// Create libp2p host. TCP (TLS1.3/Noise-secured) and QUIC are both
+	// enabled so peers behind UDP-hostile middleboxes still reach us over
+	// TCP, while QUIC's 0-RTT handshake cuts connection setup time for
+	// everyone else.
 	// DisableRelay: WireGuard overlay handles all connectivity
 	h, err := libp2p.New(
 		libp2p.Identity(privKey),
-		libp2p.ListenAddrStrings(listenAddr),
+		libp2p.ListenAddrStrings(listenAddrTCP, listenAddrQUIC),
 		libp2p.AddrsFactory(func(addrs []multiaddr.Multiaddr) []multiaddr.Multiaddr {
-			// Advertise only the external address to the DHT
-			return []multiaddr.Multiaddr{extMA}
+			// Advertise only the external addresses to the DHT
+			return extMAs
 		}),
 		libp2p.DisableRelay(),
+		libp2p.ConnectionManager(cm),
+		libp2p.ResourceManager(rm),
+		libp2p.Peerstore(pstore),
 	)
 	if err != nil {
+		pstore.Close()
+		store.Close()
 		return nil, fmt.Errorf("create libp2p host: %w", err)
 	}
 
 	logger.Info("libp2p host created",
 		"peerID", h.ID().String(),
-		"listenAddr", listenAddr,
-		"advertiseAddr", externalAddr,
+		"listenAddrs", []string{listenAddrTCP, listenAddrQUIC},
+		"advertiseAddrs", []string{externalAddrTCP, externalAddrQUIC},
 	)
 
-	// Create Kademlia DHT in server mode (full participant)
-	kadDHT, err := dht.New(ctx, h,
-		dht.Mode(dht.ModeServer),
-		dht.ProtocolPrefix("/decloud"),
-	)
+	// Create the Kademlia routing table(s) for the configured mode.
+	routingBackend, wanDHT, lanDHT, err := newRouting(ctx, h, cfg, store)
 	if err != nil {
 		h.Close()
-		return nil, fmt.Errorf("create kademlia DHT: %w", err)
+		store.Close()
+		return nil, err
 	}
 
-	// Bootstrap the DHT
-	if err := kadDHT.Bootstrap(ctx); err != nil {
+	// Bootstrap the DHT. Bounded by a short timeout so a slow or
+	// unreachable WAN doesn't block startup of a LAN-only deployment.
+	bootstrapCtx, cancelBootstrap := context.WithTimeout(ctx, 10*time.Second)
+	err = routingBackend.Bootstrap(bootstrapCtx)
+	cancelBootstrap()
+	if err != nil {
+		closeRouting(wanDHT, lanDHT)
 		h.Close()
+		store.Close()
 		return nil, fmt.Errorf("bootstrap DHT: %w", err)
 	}
 
@@ -121,25 +229,64 @@ func New(ctx context.Context, cfg *config.Config) (*Node, error) {
 
 	logger.Info("bootstrap complete", "connected", bootstrapCount, "total", len(cfg.BootstrapPeers))
 
-	// Create GossipSub
-	ps, err := pubsub.NewGossipSub(ctx, h)
+	node := &Node{
+		Host:         h,
+		DHT:          routingBackend,
+		wan:          wanDHT,
+		lan:          lanDHT,
+		identity:     privKey,
+		Config:       cfg,
+		store:        store,
+		providedKeys: make(map[string]providedKey),
+		topics:       make(map[string]*pubsub.Topic),
+		trustedPeers: make(map[peer.ID]struct{}),
+		logger:       logger,
+	}
+
+	for _, idStr := range cfg.AuthorizedPeers {
+		pid, err := peer.Decode(idStr)
+		if err != nil {
+			logger.Warn("invalid authorized peer id, skipping", "peer", idStr, "error", err)
+			continue
+		}
+		node.trustedPeers[pid] = struct{}{}
+	}
+
+	scoreOverrides, err := loadScoreOverrides(cfg.PeerScoreParamsFile)
 	if err != nil {
-		kadDHT.Close()
+		closeRouting(wanDHT, lanDHT)
 		h.Close()
-		return nil, fmt.Errorf("create gossipsub: %w", err)
+		store.Close()
+		return nil, fmt.Errorf("load peer score params: %w", err)
 	}
 
-	node := &Node{
-		Host:   h,
-		DHT:    kadDHT,
-		PubSub: ps,
-		Config: cfg,
-		topics: make(map[string]*pubsub.Topic),
-		logger: logger,
+	// Create GossipSub with peer scoring enabled, so misbehaving or
+	// low-quality peers are gradually graylisted rather than trusted
+	// indefinitely. Message signing and strict signature verification are
+	// requested explicitly so a message's "from" field is always
+	// cryptographically authenticated, even though they're GossipSub's
+	// own default.
+	ps, err := pubsub.NewGossipSub(ctx, h,
+		pubsub.WithMessageSigning(true),
+		pubsub.WithStrictSignatureVerification(true),
+		pubsub.WithPeerScore(peerScoreParams(scoreOverrides), peerScoreThresholds(scoreOverrides)),
+		pubsub.WithPeerScoreInspect(node.recordPeerScores, 10*time.Second),
+	)
+	if err != nil {
+		closeRouting(wanDHT, lanDHT)
+		h.Close()
+		store.Close()
+		return nil, fmt.Errorf("create gossipsub: %w", err)
 	}
+	node.PubSub = ps
 
-	// Join default topics
+	// Join default topics, guarding each with the default validator so only
+	// trusted peers (per AuthorizedPeers) publishing the expected schema
+	// version are delivered to subscribers.
 	for _, topic := range []string{TopicHealth, TopicEvents, TopicBlocks} {
+		if err := node.RegisterTopicValidator(topic, node.defaultTopicValidator()); err != nil {
+			logger.Warn("failed to register topic validator", "topic", topic, "error", err)
+		}
 		if _, err := node.JoinTopic(topic); err != nil {
 			logger.Warn("failed to join topic", "topic", topic, "error", err)
 		}
@@ -151,25 +298,60 @@ func New(ctx context.Context, cfg *config.Config) (*Node, error) {
 		logger.Warn("mDNS discovery failed to start", "error", err)
 	}
 
+	// Start continuously reconciling bootstrap peer connections, so the
+	// fleet self-heals as VMs come and go without a node restart.
+	bootstrapCtx, stopBootstrap := context.WithCancel(context.Background())
+	node.stopBootstrap = stopBootstrap
+	reconciler, err := newBootstrapReconciler(cfg, h, logger)
+	if err != nil {
+		logger.Warn("bootstrap reconciler disabled", "error", err)
+	} else {
+		go reconciler.Run(bootstrapCtx)
+	}
+
+	// Start the background re-provider loop, so provider records this node
+	// publishes via Provide/ProvideBlob don't expire from the DHT.
+	reprovideCtx, stopReprovide := context.WithCancel(context.Background())
+	node.stopReprovide = stopReprovide
+	go node.runReprovideLoop(reprovideCtx, cfg.RebroadcastInterval)
+
+	// Start advertising and discovering peers under the node's default
+	// rendezvous namespace.
+	node.discovery = discovery.New(h, routingBackend, cfg.Rendezvous, cfg.RendezvousTTL, logger)
+
 	return node, nil
 }
 
-// JoinTopic joins a GossipSub topic and returns it.
-func (n *Node) JoinTopic(name string) (*pubsub.Topic, error) {
-	n.mu.Lock()
-	defer n.mu.Unlock()
+// newBootstrapReconciler builds the bootstrap.Reconciler for the configured
+// sources: the static DHT_BOOTSTRAP_PEERS list plus whichever of DNS,
+// well-known URL, and orchestrator callback are configured.
+func newBootstrapReconciler(cfg *config.Config, h host.Host, logger *slog.Logger) (*bootstrap.Reconciler, error) {
+	sources := []bootstrap.Source{bootstrap.NewStaticSource(cfg.BootstrapPeers)}
 
-	if t, ok := n.topics[name]; ok {
-		return t, nil
+	if cfg.BootstrapDNSDomain != "" {
+		dnsSource, err := bootstrap.NewDNSSource(cfg.BootstrapDNSDomain)
+		if err != nil {
+			return nil, fmt.Errorf("dns bootstrap source: %w", err)
+		}
+		sources = append(sources, dnsSource)
 	}
 
-	t, err := n.PubSub.Join(name)
-	if err != nil {
-		return nil, err
+	if cfg.BootstrapURL != "" {
+		sources = append(sources, bootstrap.NewWellKnownSource(cfg.BootstrapURL))
 	}
 
-	n.topics[name] = t
-	return t, nil
+	if cfg.OrchestratorURL != "" {
+		sources = append(sources, bootstrap.NewOrchestratorSource(cfg.OrchestratorURL, cfg.OrchestratorToken))
+	}
+
+	connect := func(ctx context.Context, pi peer.AddrInfo) error {
+		if pi.ID == h.ID() {
+			return nil
+		}
+		return h.Connect(ctx, pi)
+	}
+
+	return bootstrap.NewReconciler(sources, cfg.BootstrapReconcileInterval, connect, logger), nil
 }
 
 // PeerID returns this node's libp2p peer ID string.
@@ -182,9 +364,46 @@ func (n *Node) ConnectedPeers() int {
 	return len(n.Host.Network().Peers())
 }
 
-// RoutingTableSize returns the number of peers in the Kademlia routing table.
+// RoutingTableSize returns the combined number of peers across all active
+// Kademlia routing tables (WAN and/or LAN, depending on Config.Mode).
 func (n *Node) RoutingTableSize() int {
-	return n.DHT.RoutingTable().Size()
+	wan, lan := n.RoutingTableSizes()
+	return wan + lan
+}
+
+// RoutingTableSizes returns the WAN and LAN routing table sizes separately.
+// A network that is not active in the current mode reports 0.
+func (n *Node) RoutingTableSizes() (wan, lan int) {
+	if n.wan != nil {
+		wan = n.wan.RoutingTable().Size()
+	}
+	if n.lan != nil {
+		lan = n.lan.RoutingTable().Size()
+	}
+	return wan, lan
+}
+
+// RoutingForScope returns the routing backend to query for the given scope:
+// "wan" or "lan" for a specific network, "any"/"" for the node's default
+// multiplexed backend (DHT). It errors if the requested network is not
+// active in the node's current mode.
+func (n *Node) RoutingForScope(scope string) (routing.Routing, error) {
+	switch scope {
+	case "", "any":
+		return n.DHT, nil
+	case "wan":
+		if n.wan == nil {
+			return nil, fmt.Errorf("wan routing is not active in mode %q", n.Config.Mode)
+		}
+		return n.wan, nil
+	case "lan":
+		if n.lan == nil {
+			return nil, fmt.Errorf("lan routing is not active in mode %q", n.Config.Mode)
+		}
+		return n.lan, nil
+	default:
+		return nil, fmt.Errorf("invalid scope %q: must be wan, lan, or any", scope)
+	}
 }
 
 // Close shuts down the DHT node.
@@ -192,15 +411,132 @@ func (n *Node) Close() error {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
+	if n.stopBootstrap != nil {
+		n.stopBootstrap()
+	}
+	if n.stopReprovide != nil {
+		n.stopReprovide()
+	}
+	if n.discovery != nil {
+		n.discovery.Close()
+	}
+
 	for _, t := range n.topics {
 		t.Close()
 	}
 
-	if err := n.DHT.Close(); err != nil {
-		n.logger.Warn("error closing DHT", "error", err)
+	closeRouting(n.wan, n.lan)
+
+	// Host.Close() also closes the peerstore (datastore-backed, sharing
+	// n.store), so the datastore itself must be closed last.
+	hostErr := n.Host.Close()
+
+	if err := n.store.Close(); err != nil {
+		n.logger.Warn("error closing datastore", "error", err)
+	}
+
+	return hostErr
+}
+
+// dhtModeOpt converts the WANMode/LANMode config strings to the dht.ModeOpt
+// the Kademlia implementation expects.
+func dhtModeOpt(mode string) (dht.ModeOpt, error) {
+	switch mode {
+	case "", config.DHTModeServer:
+		return dht.ModeServer, nil
+	case config.DHTModeClient:
+		return dht.ModeClient, nil
+	default:
+		return 0, fmt.Errorf("invalid DHT server/client mode %q", mode)
 	}
+}
 
-	return n.Host.Close()
+// newRouting constructs the Kademlia routing table(s) for the given mode,
+// returning the multiplexed backend used by default plus the concrete
+// per-network DHTs (whichever are active) for scoped queries and size
+// reporting.
+func newRouting(ctx context.Context, h host.Host, cfg *config.Config, store ds.Batching) (rt routing.Routing, wan, lan *dht.IpfsDHT, err error) {
+	signedValidator := dht.NamespacedValidator(signedRecordNamespace, records.Validator{})
+
+	wanModeOpt, err := dhtModeOpt(cfg.WANMode)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	lanModeOpt, err := dhtModeOpt(cfg.LANMode)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	switch cfg.Mode {
+	case "", config.ModeWAN:
+		pm, err := providers.NewProviderManager(h.ID(), h.Peerstore(), store)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create provider manager: %w", err)
+		}
+		wanDHT, err := dht.New(ctx, h, dht.Mode(wanModeOpt), dht.ProtocolPrefix("/decloud"), signedValidator, dht.Datastore(store), dht.ProviderStore(pm))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create kademlia DHT: %w", err)
+		}
+		return wanDHT, wanDHT, nil, nil
+
+	case config.ModeLAN:
+		pm, err := providers.NewProviderManager(h.ID(), h.Peerstore(), store)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create provider manager: %w", err)
+		}
+		lanDHT, err := dht.New(ctx, h, dht.Mode(lanModeOpt), dht.ProtocolPrefix("/decloud/lan"), signedValidator, dht.Datastore(store), dht.ProviderStore(pm),
+			dht.QueryFilter(dht.PrivateQueryFilter), dht.RoutingTableFilter(dht.PrivateRoutingTableFilter))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create kademlia DHT: %w", err)
+		}
+		return lanDHT, nil, lanDHT, nil
+
+	case config.ModeDual:
+		// WAN and LAN share the underlying Badger datastore but are kept
+		// in separate namespaces so their routing/provider records can't
+		// collide. They also run under separate protocol prefixes
+		// ("/decloud/wan", "/decloud/lan") so a WAN-only and a LAN-only
+		// peer can never mistake each other's Kademlia traffic for the
+		// other network's.
+		wanStore := namespace.Wrap(store, ds.NewKey("/wan"))
+		lanStore := namespace.Wrap(store, ds.NewKey("/lan"))
+
+		wanPM, err := providers.NewProviderManager(h.ID(), h.Peerstore(), wanStore)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create WAN provider manager: %w", err)
+		}
+		lanPM, err := providers.NewProviderManager(h.ID(), h.Peerstore(), lanStore)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create LAN provider manager: %w", err)
+		}
+
+		dualDHT, err := dual.New(ctx, h,
+			dual.WanDHTOption(dht.Mode(wanModeOpt), dht.ProtocolPrefix("/decloud/wan"), signedValidator, dht.Datastore(wanStore), dht.ProviderStore(wanPM)),
+			dual.LanDHTOption(dht.Mode(lanModeOpt), dht.ProtocolPrefix("/decloud/lan"), signedValidator, dht.Datastore(lanStore), dht.ProviderStore(lanPM)),
+		)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("create dual kademlia DHT: %w", err)
+		}
+		return dualDHT, dualDHT.WAN, dualDHT.LAN, nil
+
+	default:
+		return nil, nil, nil, fmt.Errorf("invalid DHT mode %q", cfg.Mode)
+	}
+}
+
+// closeRouting closes whichever of wan/lan are non-nil, logging failures
+// without masking the caller's own shutdown path.
+func closeRouting(wan, lan *dht.IpfsDHT) {
+	if wan != nil {
+		if err := wan.Close(); err != nil {
+			slog.Default().Warn("error closing WAN DHT", "error", err)
+		}
+	}
+	if lan != nil && lan != wan {
+		if err := lan.Close(); err != nil {
+			slog.Default().Warn("error closing LAN DHT", "error", err)
+		}
+	}
 }
 
 // loadOrCreateKey loads a persistent identity key or creates a new one.
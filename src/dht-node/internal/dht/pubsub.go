@@ -0,0 +1,157 @@
+package dht
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// defaultTopicSchemaVersion is the schema version defaultTopicValidator
+// requires on messages published to the default topics (TopicHealth,
+// TopicEvents, TopicBlocks). Bump it when their message format changes in
+// a way that isn't backward compatible.
+const defaultTopicSchemaVersion = 1
+
+// TopicEnvelope is the envelope defaultTopicValidator expects every message
+// on a default topic to carry: a schema version plus an arbitrary payload.
+type TopicEnvelope struct {
+	Version int             `json:"v"`
+	Payload json.RawMessage `json:"payload"`
+}
+
+// JoinTopic joins a GossipSub topic and returns it.
+func (n *Node) JoinTopic(name string) (*pubsub.Topic, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if t, ok := n.topics[name]; ok {
+		return t, nil
+	}
+
+	t, err := n.PubSub.Join(name)
+	if err != nil {
+		return nil, err
+	}
+
+	n.topics[name] = t
+	return t, nil
+}
+
+// Subscribe joins the topic if needed and returns a subscription to its
+// messages. Callers are responsible for cancelling the subscription.
+func (n *Node) Subscribe(name string) (*pubsub.Subscription, error) {
+	t, err := n.JoinTopic(name)
+	if err != nil {
+		return nil, err
+	}
+	return t.Subscribe()
+}
+
+// Topics returns the names of the topics this node currently holds an
+// active subscription to. Topics that are joined but not subscribed to are
+// not included.
+func (n *Node) Topics() []string {
+	return n.PubSub.GetTopics()
+}
+
+// TopicPeers returns the connected peers known to be subscribed to a given
+// topic, whether or not they are in this node's mesh for it.
+func (n *Node) TopicPeers(name string) []peer.ID {
+	return n.PubSub.ListPeers(name)
+}
+
+// RegisterAllowListValidator installs a topic validator that rejects any
+// message whose sender is not in the given allow-list. It calls through to
+// RegisterTopicValidator so GossipSub drops disallowed messages before they
+// reach subscribers.
+func (n *Node) RegisterAllowListValidator(topic string, allowed []peer.ID) error {
+	allowedSet := make(map[peer.ID]struct{}, len(allowed))
+	for _, p := range allowed {
+		allowedSet[p] = struct{}{}
+	}
+
+	return n.RegisterTopicValidator(topic, pubsub.ValidatorEx(func(_ context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		if _, ok := allowedSet[msg.GetFrom()]; !ok {
+			return pubsub.ValidationReject
+		}
+		return pubsub.ValidationAccept
+	}))
+}
+
+// RegisterTopicValidator installs v as the GossipSub validator for topic,
+// rejecting any message v doesn't accept before it reaches subscribers. Any
+// validator already registered for topic is replaced: RegisterTopicValidator
+// errors with "duplicate validator for topic ..." if one is already in
+// place, so this unregisters first and ignores the "no validator for topic
+// ..." error that comes back when there wasn't one to begin with.
+func (n *Node) RegisterTopicValidator(topic string, v pubsub.ValidatorEx) error {
+	if err := n.PubSub.UnregisterTopicValidator(topic); err != nil && !strings.Contains(err.Error(), "no validator for topic") {
+		return err
+	}
+	return n.PubSub.RegisterTopicValidator(topic, v)
+}
+
+// defaultTopicValidator builds the validator used on the default topics
+// (TopicHealth, TopicEvents, TopicBlocks): it requires the sender to be a
+// trusted peer (see AddTrustedPeer) and the message to be a well-formed
+// TopicEnvelope at the expected schema version.
+func (n *Node) defaultTopicValidator() pubsub.ValidatorEx {
+	return func(_ context.Context, _ peer.ID, msg *pubsub.Message) pubsub.ValidationResult {
+		if !n.isTrustedPeer(msg.GetFrom()) {
+			return pubsub.ValidationReject
+		}
+
+		var env TopicEnvelope
+		if err := json.Unmarshal(msg.GetData(), &env); err != nil {
+			return pubsub.ValidationReject
+		}
+		if env.Version != defaultTopicSchemaVersion {
+			return pubsub.ValidationReject
+		}
+
+		return pubsub.ValidationAccept
+	}
+}
+
+// isTrustedPeer reports whether p is allowed to publish to the default
+// topics. An empty trust set means no restriction.
+func (n *Node) isTrustedPeer(p peer.ID) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if len(n.trustedPeers) == 0 {
+		return true
+	}
+	_, ok := n.trustedPeers[p]
+	return ok
+}
+
+// TrustedPeers returns the peer IDs currently allowed to publish to the
+// default topics. An empty slice means no restriction is in effect.
+func (n *Node) TrustedPeers() []peer.ID {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	result := make([]peer.ID, 0, len(n.trustedPeers))
+	for p := range n.trustedPeers {
+		result = append(result, p)
+	}
+	return result
+}
+
+// AddTrustedPeer adds p to the default topics' allow-list.
+func (n *Node) AddTrustedPeer(p peer.ID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.trustedPeers[p] = struct{}{}
+}
+
+// RemoveTrustedPeer removes p from the default topics' allow-list.
+func (n *Node) RemoveTrustedPeer(p peer.ID) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.trustedPeers, p)
+}
@@ -0,0 +1,136 @@
+// Package records implements a signed, sequenced value envelope for the
+// Kademlia DHT, so that a key can only be overwritten by the peer that
+// originally wrote it. Values are wrapped in an Envelope carrying the
+// author's public key, an ed25519 signature, and a monotonic sequence
+// number, and verified on GetValue via a libp2p record.Validator.
+//
+// A signature alone doesn't prevent a forged keypair from writing someone
+// else's key — it only proves the envelope is self-consistent. Ownership
+// is enforced the way IPNS binds "/ipns/<peerID>": the DHT key itself
+// encodes the owning peer ID (see KeyOwner), and Validator rejects any
+// envelope whose signer doesn't match that key.
+//
+// Sequence numbers follow "highest-seq wins, signature-verified" semantics:
+// republishes converge deterministically across the cluster because every
+// peer picks the same value out of a set of candidates.
+package records
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// Envelope is a signed, sequenced wrapper around an arbitrary payload.
+type Envelope struct {
+	Payload []byte `json:"payload"`
+	PubKey  []byte `json:"pubkey"` // marshalled libp2p public key
+	Sig     []byte `json:"sig"`
+	Seq     uint64 `json:"seq"`
+	TTL     int64  `json:"ttl"` // unix seconds after which the record is considered expired
+}
+
+// Sign builds and signs an Envelope containing payload, valid for ttl from
+// now, using priv as the author's identity key.
+func Sign(priv crypto.PrivKey, payload []byte, seq uint64, ttl time.Duration) (*Envelope, error) {
+	pubBytes, err := crypto.MarshalPublicKey(priv.GetPublic())
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key: %w", err)
+	}
+
+	env := &Envelope{
+		Payload: payload,
+		PubKey:  pubBytes,
+		Seq:     seq,
+		TTL:     time.Now().Add(ttl).Unix(),
+	}
+
+	sig, err := priv.Sign(signedBytes(env))
+	if err != nil {
+		return nil, fmt.Errorf("sign envelope: %w", err)
+	}
+	env.Sig = sig
+
+	return env, nil
+}
+
+// Marshal encodes the envelope for storage as a raw DHT value.
+func Marshal(env *Envelope) ([]byte, error) {
+	return json.Marshal(env)
+}
+
+// Unmarshal decodes a raw DHT value back into an Envelope.
+func Unmarshal(data []byte) (*Envelope, error) {
+	var env Envelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil, fmt.Errorf("unmarshal envelope: %w", err)
+	}
+	return &env, nil
+}
+
+// Verify checks the envelope's signature and expiry, returning the signer's
+// peer ID on success.
+func Verify(env *Envelope) (peer.ID, error) {
+	if time.Now().Unix() > env.TTL {
+		return "", fmt.Errorf("record expired")
+	}
+
+	pub, err := crypto.UnmarshalPublicKey(env.PubKey)
+	if err != nil {
+		return "", fmt.Errorf("unmarshal public key: %w", err)
+	}
+
+	ok, err := pub.Verify(signedBytes(env), env.Sig)
+	if err != nil {
+		return "", fmt.Errorf("verify signature: %w", err)
+	}
+	if !ok {
+		return "", fmt.Errorf("invalid signature")
+	}
+
+	id, err := peer.IDFromPublicKey(pub)
+	if err != nil {
+		return "", fmt.Errorf("derive peer id: %w", err)
+	}
+	return id, nil
+}
+
+// signedBytes returns the canonical bytes a signature covers: the payload,
+// sequence number, public key, and TTL, in that order. TTL is covered so a
+// peer that stores or relays the record can't extend or shorten its
+// validity without invalidating the signature. Only the signature itself is
+// excluded, since it's what's being computed/checked.
+func signedBytes(env *Envelope) []byte {
+	buf := make([]byte, 0, len(env.Payload)+8+len(env.PubKey)+8)
+	buf = append(buf, env.Payload...)
+	var seqBuf [8]byte
+	binary.BigEndian.PutUint64(seqBuf[:], env.Seq)
+	buf = append(buf, seqBuf[:]...)
+	buf = append(buf, env.PubKey...)
+	var ttlBuf [8]byte
+	binary.BigEndian.PutUint64(ttlBuf[:], uint64(env.TTL))
+	buf = append(buf, ttlBuf[:]...)
+	return buf
+}
+
+// KeyOwner extracts the owning peer ID from a signed-record DHT key of the
+// form "/<validator-ns>/<caller-namespace>/<peerID>/<subkey>", as written by
+// Node.PutSigned. Validator uses it to bind a key to the one peer allowed
+// to write it.
+func KeyOwner(key string) (peer.ID, error) {
+	parts := strings.SplitN(strings.TrimPrefix(key, "/"), "/", 4)
+	if len(parts) < 3 {
+		return "", fmt.Errorf("malformed signed record key %q", key)
+	}
+
+	owner, err := peer.Decode(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid owner peer id in key %q: %w", key, err)
+	}
+	return owner, nil
+}
@@ -0,0 +1,70 @@
+package records
+
+import "fmt"
+
+// Validator is a libp2p record.Validator for signed envelopes. It rejects
+// malformed, unsigned, or wrong-owner values, and selects the highest
+// sequence number among same-owner candidates, so republishes converge
+// without needing coordination.
+type Validator struct{}
+
+// Validate checks that value is a well-formed, signed, unexpired Envelope
+// whose signer matches the owner peer ID encoded in key (see KeyOwner).
+// Without this check, anyone could mint a fresh keypair, self-sign an
+// envelope, and have it accepted for a key they don't own.
+func (Validator) Validate(key string, value []byte) error {
+	env, err := Unmarshal(value)
+	if err != nil {
+		return err
+	}
+
+	signer, err := Verify(env)
+	if err != nil {
+		return fmt.Errorf("invalid signed record: %w", err)
+	}
+
+	owner, err := KeyOwner(key)
+	if err != nil {
+		return fmt.Errorf("invalid signed record key: %w", err)
+	}
+	if signer != owner {
+		return fmt.Errorf("record signer %s does not own key (owner %s)", signer, owner)
+	}
+
+	return nil
+}
+
+// Select returns the index of the candidate with the highest sequence
+// number among those actually signed by key's owner, discarding any
+// forged or wrong-owner candidate outright.
+func (Validator) Select(key string, values [][]byte) (int, error) {
+	if len(values) == 0 {
+		return 0, fmt.Errorf("can't select from no values")
+	}
+
+	owner, err := KeyOwner(key)
+	if err != nil {
+		return 0, fmt.Errorf("invalid signed record key: %w", err)
+	}
+
+	best := -1
+	var bestEnv *Envelope
+	for i, v := range values {
+		env, err := Unmarshal(v)
+		if err != nil {
+			continue
+		}
+		signer, err := Verify(env)
+		if err != nil || signer != owner {
+			continue
+		}
+		if bestEnv == nil || env.Seq > bestEnv.Seq {
+			bestEnv = env
+			best = i
+		}
+	}
+	if bestEnv == nil {
+		return 0, fmt.Errorf("no valid candidates")
+	}
+	return best, nil
+}
@@ -0,0 +1,150 @@
+package dht
+
+import (
+	"context"
+	"time"
+
+	cid "github.com/ipfs/go-cid"
+	"github.com/libp2p/go-libp2p/core/peer"
+	mh "github.com/multiformats/go-multihash"
+)
+
+// providedKey is a single key this node has announced itself as a provider
+// for, tracked so Reprovide can re-announce it before its DHT provider
+// record expires (see Config.ProvideValidity / RebroadcastInterval). scope
+// records which routing backend (see RoutingForScope) the key was provided
+// on, so Reprovide re-announces it on the same network.
+type providedKey struct {
+	c     cid.Cid
+	scope string
+}
+
+// ReprovideStatus reports the outcome of a Reprovide sweep.
+type ReprovideStatus struct {
+	LastRun  time.Time     `json:"lastRun"`
+	Count    int           `json:"count"`
+	Errors   int           `json:"errors"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Provide announces this node as a provider of c on the routing backend
+// selected by scope (see RoutingForScope), and remembers the key and scope
+// so future Reprovide sweeps keep its provider record alive past
+// Config.ProvideValidity on the same network.
+func (n *Node) Provide(ctx context.Context, c cid.Cid, scope string, announce bool) error {
+	rt, err := n.RoutingForScope(scope)
+	if err != nil {
+		return err
+	}
+
+	if err := rt.Provide(ctx, c, announce); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	n.providedKeys[c.String()] = providedKey{c: c, scope: scope}
+	n.mu.Unlock()
+
+	return nil
+}
+
+// ProvideBlob hashes data into a CIDv1 and announces this node as its
+// provider, for use alongside content published on the decloud/blocks
+// topic. It returns the CID so the caller can reference the same content
+// elsewhere (e.g. in the GossipSub message).
+func (n *Node) ProvideBlob(ctx context.Context, data []byte, scope string, announce bool) (cid.Cid, error) {
+	hash, err := mh.Sum(data, mh.SHA2_256, -1)
+	if err != nil {
+		return cid.Undef, err
+	}
+
+	c := cid.NewCidV1(cid.Raw, hash)
+	if err := n.Provide(ctx, c, scope, announce); err != nil {
+		return cid.Undef, err
+	}
+
+	return c, nil
+}
+
+// FindProviders returns up to count peers known to provide c, querying the
+// routing backend selected by scope (see RoutingForScope).
+func (n *Node) FindProviders(ctx context.Context, c cid.Cid, scope string, count int) ([]peer.AddrInfo, error) {
+	rt, err := n.RoutingForScope(scope)
+	if err != nil {
+		return nil, err
+	}
+
+	ch := rt.FindProvidersAsync(ctx, c, count)
+
+	result := make([]peer.AddrInfo, 0, count)
+	for pi := range ch {
+		result = append(result, pi)
+	}
+	return result, nil
+}
+
+// Reprovide re-announces every key this node has previously provided via
+// Provide or ProvideBlob, refreshing their DHT provider records. It is
+// safe to call concurrently with Provide.
+func (n *Node) Reprovide(ctx context.Context) ReprovideStatus {
+	n.mu.RLock()
+	keys := make([]providedKey, 0, len(n.providedKeys))
+	for _, k := range n.providedKeys {
+		keys = append(keys, k)
+	}
+	n.mu.RUnlock()
+
+	start := time.Now()
+	errCount := 0
+
+	for _, k := range keys {
+		rt, err := n.RoutingForScope(k.scope)
+		if err != nil {
+			n.logger.Warn("reprovide failed", "key", k.c, "scope", k.scope, "error", err)
+			errCount++
+			continue
+		}
+		if err := rt.Provide(ctx, k.c, true); err != nil {
+			n.logger.Warn("reprovide failed", "key", k.c, "scope", k.scope, "error", err)
+			errCount++
+		}
+	}
+
+	status := ReprovideStatus{
+		LastRun:  start,
+		Count:    len(keys),
+		Errors:   errCount,
+		Duration: time.Since(start),
+	}
+
+	n.mu.Lock()
+	n.lastReprovide = status
+	n.mu.Unlock()
+
+	return status
+}
+
+// LastReprovideStatus returns the outcome of the most recent Reprovide
+// sweep, or the zero value if none has run yet.
+func (n *Node) LastReprovideStatus() ReprovideStatus {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.lastReprovide
+}
+
+// runReprovideLoop periodically calls Reprovide until ctx is cancelled, so
+// provider records this node has published don't expire from the DHT.
+func (n *Node) runReprovideLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			status := n.Reprovide(ctx)
+			n.logger.Info("reprovide sweep complete", "count", status.Count, "errors", status.Errors, "duration", status.Duration)
+		}
+	}
+}
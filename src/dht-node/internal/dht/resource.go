@@ -0,0 +1,34 @@
+package dht
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	rcmgr "github.com/libp2p/go-libp2p/p2p/host/resource-manager"
+)
+
+// newResourceManager builds the libp2p resource manager. If limitsFile is
+// set, it is read as a JSON rcmgr.PartialLimitConfig overlaid on the
+// auto-scaled defaults; otherwise the auto-scaled defaults are used as-is.
+// Without a resource manager, a single misbehaving or malicious peer can
+// exhaust file descriptors or memory on the DHT VM.
+func newResourceManager(limitsFile string) (network.ResourceManager, error) {
+	if limitsFile == "" {
+		limiter := rcmgr.NewFixedLimiter(rcmgr.DefaultLimits.AutoScale())
+		return rcmgr.NewResourceManager(limiter)
+	}
+
+	f, err := os.Open(limitsFile)
+	if err != nil {
+		return nil, fmt.Errorf("open resource manager limits file: %w", err)
+	}
+	defer f.Close()
+
+	limiter, err := rcmgr.NewLimiterFromJSON(f, rcmgr.DefaultLimits.AutoScale())
+	if err != nil {
+		return nil, fmt.Errorf("parse resource manager limits file: %w", err)
+	}
+
+	return rcmgr.NewResourceManager(limiter)
+}
@@ -0,0 +1,152 @@
+package dht
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// scoreOverrides is the JSON shape accepted by Config.PeerScoreParamsFile: a
+// partial overlay on the built-in peer score defaults below, mirroring how
+// ResourceManagerLimitsFile overlays rcmgr's auto-scaled defaults. A field
+// left out or null keeps its default; set fields apply uniformly across
+// TopicHealth/TopicEvents/TopicBlocks, the same way the defaults do. Weight
+// names follow GossipSub's P1-P4 score components: TimeInMeshWeight (P1),
+// FirstMessageDeliveriesWeight (P2), MeshMessageDeliveriesWeight (P3),
+// InvalidMessageDeliveriesWeight (P4).
+type scoreOverrides struct {
+	TimeInMeshWeight               *float64 `json:"timeInMeshWeight,omitempty"`
+	FirstMessageDeliveriesWeight   *float64 `json:"firstMessageDeliveriesWeight,omitempty"`
+	MeshMessageDeliveriesWeight    *float64 `json:"meshMessageDeliveriesWeight,omitempty"`
+	InvalidMessageDeliveriesWeight *float64 `json:"invalidMessageDeliveriesWeight,omitempty"`
+
+	GossipThreshold   *float64 `json:"gossipThreshold,omitempty"`
+	PublishThreshold  *float64 `json:"publishThreshold,omitempty"`
+	GraylistThreshold *float64 `json:"graylistThreshold,omitempty"`
+}
+
+// loadScoreOverrides reads and parses file into a scoreOverrides. An empty
+// file path means "no overrides": it returns the zero value, which leaves every
+// default in peerScoreParams/peerScoreThresholds untouched.
+func loadScoreOverrides(file string) (*scoreOverrides, error) {
+	if file == "" {
+		return &scoreOverrides{}, nil
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, fmt.Errorf("open peer score params file: %w", err)
+	}
+	defer f.Close()
+
+	var o scoreOverrides
+	if err := json.NewDecoder(f).Decode(&o); err != nil {
+		return nil, fmt.Errorf("parse peer score params file: %w", err)
+	}
+	return &o, nil
+}
+
+// peerScoreParams returns GossipSub peer score parameters tuned for a
+// small, fixed set of DeCloud topics, with any operator-supplied overrides
+// layered on top. Untrusted regions can publish to these topics, so
+// invalid-message and IP-colocation penalties are the primary defense
+// against flooding.
+func peerScoreParams(o *scoreOverrides) *pubsub.PeerScoreParams {
+	topicParams := &pubsub.TopicScoreParams{
+		TopicWeight:                     1,
+		TimeInMeshWeight:                0.01,
+		TimeInMeshQuantum:               1,
+		TimeInMeshCap:                   10,
+		FirstMessageDeliveriesWeight:    1,
+		FirstMessageDeliveriesDecay:     0.5,
+		FirstMessageDeliveriesCap:       10,
+		MeshMessageDeliveriesWeight:     -1,
+		MeshMessageDeliveriesDecay:      0.5,
+		MeshMessageDeliveriesCap:        10,
+		MeshMessageDeliveriesThreshold:  1,
+		MeshMessageDeliveriesActivation: 10 * time.Second,
+		MeshFailurePenaltyWeight:        -1,
+		MeshFailurePenaltyDecay:         0.5,
+		InvalidMessageDeliveriesWeight:  -20,
+		InvalidMessageDeliveriesDecay:   0.5,
+	}
+	if o.TimeInMeshWeight != nil {
+		topicParams.TimeInMeshWeight = *o.TimeInMeshWeight
+	}
+	if o.FirstMessageDeliveriesWeight != nil {
+		topicParams.FirstMessageDeliveriesWeight = *o.FirstMessageDeliveriesWeight
+	}
+	if o.MeshMessageDeliveriesWeight != nil {
+		topicParams.MeshMessageDeliveriesWeight = *o.MeshMessageDeliveriesWeight
+	}
+	if o.InvalidMessageDeliveriesWeight != nil {
+		topicParams.InvalidMessageDeliveriesWeight = *o.InvalidMessageDeliveriesWeight
+	}
+
+	return &pubsub.PeerScoreParams{
+		Topics: map[string]*pubsub.TopicScoreParams{
+			TopicHealth: topicParams,
+			TopicEvents: topicParams,
+			TopicBlocks: topicParams,
+		},
+		TopicScoreCap:               20,
+		AppSpecificScore:            func(peer.ID) float64 { return 0 },
+		AppSpecificWeight:           1,
+		IPColocationFactorWeight:    -5,
+		IPColocationFactorThreshold: 3,
+		BehaviourPenaltyWeight:      -10,
+		BehaviourPenaltyDecay:       0.5,
+		DecayInterval:               pubsub.DefaultDecayInterval,
+		DecayToZero:                 pubsub.DefaultDecayToZero,
+		RetainScore:                 pubsub.DefaultDecayInterval,
+	}
+}
+
+// peerScoreThresholds returns the global GossipSub score thresholds that
+// gate mesh membership, gossip emission, and publishing, with any
+// operator-supplied overrides layered on top.
+func peerScoreThresholds(o *scoreOverrides) *pubsub.PeerScoreThresholds {
+	t := &pubsub.PeerScoreThresholds{
+		GossipThreshold:             -500,
+		PublishThreshold:            -1000,
+		GraylistThreshold:           -2500,
+		AcceptPXThreshold:           1,
+		OpportunisticGraftThreshold: 2,
+	}
+	if o.GossipThreshold != nil {
+		t.GossipThreshold = *o.GossipThreshold
+	}
+	if o.PublishThreshold != nil {
+		t.PublishThreshold = *o.PublishThreshold
+	}
+	if o.GraylistThreshold != nil {
+		t.GraylistThreshold = *o.GraylistThreshold
+	}
+	return t
+}
+
+// recordPeerScores is the pubsub.WithPeerScoreInspect callback: it snapshots
+// the current per-peer scores so GET /metrics/peerscores can report them
+// without poking into GossipSub's internals on every request.
+func (n *Node) recordPeerScores(scores map[peer.ID]float64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.peerScores = scores
+}
+
+// PeerScores returns the most recent GossipSub peer score snapshot, keyed
+// by peer ID string.
+func (n *Node) PeerScores() map[string]float64 {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	result := make(map[string]float64, len(n.peerScores))
+	for p, score := range n.peerScores {
+		result[p.String()] = score
+	}
+	return result
+}
@@ -0,0 +1,75 @@
+package dht
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/decloud/dht-node/internal/dht/records"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// DefaultSignedRecordTTL is how long a signed record stays valid if the
+// caller does not specify a TTL.
+const DefaultSignedRecordTTL = 24 * time.Hour
+
+// PutSigned signs payload with the node's own identity key and writes it to
+// the DHT under /signed/{namespace}/{this node's peer ID}/{key}. The DHT key
+// encodes the signer's own peer ID, and records.Validator rejects any
+// envelope whose signer doesn't match that key, so only this node (whoever
+// holds this identity key) can ever write or overwrite it — a forged
+// keypair can't take over the key the way a bare signature check would
+// allow, since the forged key would own a different key path entirely.
+func (n *Node) PutSigned(ctx context.Context, namespace, key string, payload []byte, ttl time.Duration) error {
+	if ttl <= 0 {
+		ttl = DefaultSignedRecordTTL
+	}
+
+	// A wall-clock-derived sequence number (Unix nanoseconds) is enough for
+	// "highest-seq wins" convergence without needing a persisted counter; it
+	// only increases between writes as long as the system clock is not
+	// stepped backwards.
+	seq := uint64(time.Now().UnixNano())
+
+	env, err := records.Sign(n.identity, payload, seq, ttl)
+	if err != nil {
+		return fmt.Errorf("sign record: %w", err)
+	}
+
+	data, err := records.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("marshal record: %w", err)
+	}
+
+	return n.DHT.PutValue(ctx, signedRecordKey(namespace, n.Host.ID(), key), data)
+}
+
+// GetSigned fetches and verifies a signed record written by owner under
+// namespace/key, returning its payload and sequence number. It errors if no
+// record exists there, or if the stored record's signer doesn't actually
+// match owner.
+func (n *Node) GetSigned(ctx context.Context, namespace string, owner peer.ID, key string) (payload []byte, seq uint64, err error) {
+	value, err := n.DHT.GetValue(ctx, signedRecordKey(namespace, owner, key))
+	if err != nil {
+		return nil, 0, fmt.Errorf("get record: %w", err)
+	}
+
+	env, err := records.Unmarshal(value)
+	if err != nil {
+		return nil, 0, fmt.Errorf("unmarshal record: %w", err)
+	}
+
+	signer, err := records.Verify(env)
+	if err != nil {
+		return nil, 0, fmt.Errorf("verify record: %w", err)
+	}
+	if signer != owner {
+		return nil, 0, fmt.Errorf("record signer %s does not match requested owner %s", signer, owner)
+	}
+
+	return env.Payload, env.Seq, nil
+}
+
+func signedRecordKey(namespace string, owner peer.ID, key string) string {
+	return "/" + signedRecordNamespace + "/" + namespace + "/" + owner.String() + "/" + key
+}
@@ -0,0 +1,29 @@
+package dht
+
+import (
+	"sort"
+
+	multiaddr "github.com/multiformats/go-multiaddr"
+)
+
+// Transports returns the names of the transports this node is currently
+// listening on (e.g. "tcp", "quic-v1"), derived from its active listen
+// addresses.
+func (n *Node) Transports() []string {
+	seen := make(map[string]struct{})
+	for _, addr := range n.Host.Network().ListenAddresses() {
+		for _, proto := range addr.Protocols() {
+			switch proto.Code {
+			case multiaddr.P_TCP, multiaddr.P_QUIC_V1, multiaddr.P_QUIC, multiaddr.P_WEBTRANSPORT, multiaddr.P_WS, multiaddr.P_WSS:
+				seen[proto.Name] = struct{}{}
+			}
+		}
+	}
+
+	result := make([]string, 0, len(seen))
+	for name := range seen {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
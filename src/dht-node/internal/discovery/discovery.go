@@ -0,0 +1,207 @@
+// Package discovery advertises this node and finds peers under one or more
+// rendezvous namespaces, on top of the Kademlia DHT's content routing
+// layer. It complements bootstrap reconciliation (internal/bootstrap) by
+// helping peers that don't already know each other converge into the same
+// mesh.
+package discovery
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/discovery"
+	"github.com/libp2p/go-libp2p/core/host"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/routing"
+	routingdisc "github.com/libp2p/go-libp2p/p2p/discovery/routing"
+)
+
+// DefaultRendezvous is the default namespace DeCloud DHT nodes advertise
+// themselves under.
+const DefaultRendezvous = "decloud/v1"
+
+// DefaultAdvertiseTTL is the advertisement lifetime used when a caller
+// doesn't configure one.
+const DefaultAdvertiseTTL = time.Hour
+
+// Metrics is a snapshot of discovery activity counters.
+type Metrics struct {
+	Advertisements int `json:"advertisements"`
+	PeersFound     int `json:"peersFound"`
+	DialSuccess    int `json:"dialSuccess"`
+	DialFailure    int `json:"dialFailure"`
+}
+
+// Discovery advertises this node and finds peers under one or more
+// rendezvous namespaces, dialing any newly discovered peer it isn't
+// already connected to.
+type Discovery struct {
+	host   host.Host
+	disc   *routingdisc.RoutingDiscovery
+	ttl    time.Duration
+	logger *slog.Logger
+
+	mu         sync.Mutex
+	namespaces map[string]context.CancelFunc
+	metrics    Metrics
+}
+
+// New creates a Discovery subsystem over router (the node's Kademlia DHT)
+// and immediately starts advertising/finding peers under rendezvous.
+func New(h host.Host, router routing.ContentRouting, rendezvous string, ttl time.Duration, logger *slog.Logger) *Discovery {
+	if ttl <= 0 {
+		ttl = DefaultAdvertiseTTL
+	}
+	d := &Discovery{
+		host:       h,
+		disc:       routingdisc.NewRoutingDiscovery(router),
+		ttl:        ttl,
+		logger:     logger,
+		namespaces: make(map[string]context.CancelFunc),
+	}
+	d.Rendezvous(rendezvous)
+	return d
+}
+
+// Rendezvous starts advertising and discovering peers under namespace ns.
+// Calling it again with an already-active ns is a no-op.
+func (d *Discovery) Rendezvous(ns string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if ns == "" {
+		return
+	}
+	if _, active := d.namespaces[ns]; active {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d.namespaces[ns] = cancel
+
+	go d.advertiseLoop(ctx, ns)
+	go d.findPeersLoop(ctx, ns)
+}
+
+// StopRendezvous stops advertising and discovering peers under namespace
+// ns. It is a no-op if ns isn't currently active.
+func (d *Discovery) StopRendezvous(ns string) {
+	d.mu.Lock()
+	cancel, active := d.namespaces[ns]
+	delete(d.namespaces, ns)
+	d.mu.Unlock()
+
+	if active {
+		cancel()
+	}
+}
+
+// Namespaces returns the currently active rendezvous namespaces.
+func (d *Discovery) Namespaces() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	result := make([]string, 0, len(d.namespaces))
+	for ns := range d.namespaces {
+		result = append(result, ns)
+	}
+	return result
+}
+
+// Metrics returns a snapshot of discovery activity counters.
+func (d *Discovery) Metrics() Metrics {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.metrics
+}
+
+// Close stops advertising and discovering under every active namespace.
+func (d *Discovery) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for ns, cancel := range d.namespaces {
+		cancel()
+		delete(d.namespaces, ns)
+	}
+}
+
+// advertiseLoop re-advertises ns a bit before each advertisement's TTL
+// expires, until ctx is cancelled.
+func (d *Discovery) advertiseLoop(ctx context.Context, ns string) {
+	for {
+		actualTTL, err := d.disc.Advertise(ctx, ns, discovery.TTL(d.ttl))
+		if err != nil {
+			d.logger.Warn("discovery advertise failed", "rendezvous", ns, "error", err)
+			actualTTL = d.ttl
+		} else {
+			d.mu.Lock()
+			d.metrics.Advertisements++
+			d.mu.Unlock()
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(actualTTL - actualTTL/4):
+		}
+	}
+}
+
+// findPeersLoop periodically searches for peers under ns and dials any
+// that aren't already connected, until ctx is cancelled.
+func (d *Discovery) findPeersLoop(ctx context.Context, ns string) {
+	d.findAndDial(ctx, ns)
+
+	ticker := time.NewTicker(d.ttl / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.findAndDial(ctx, ns)
+		}
+	}
+}
+
+func (d *Discovery) findAndDial(ctx context.Context, ns string) {
+	peers, err := d.disc.FindPeers(ctx, ns)
+	if err != nil {
+		d.logger.Warn("discovery find peers failed", "rendezvous", ns, "error", err)
+		return
+	}
+
+	for pi := range peers {
+		if pi.ID == d.host.ID() {
+			continue
+		}
+
+		d.mu.Lock()
+		d.metrics.PeersFound++
+		d.mu.Unlock()
+
+		if d.host.Network().Connectedness(pi.ID) == network.Connected {
+			continue
+		}
+
+		dialCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := d.host.Connect(dialCtx, pi)
+		cancel()
+
+		d.mu.Lock()
+		if err != nil {
+			d.metrics.DialFailure++
+		} else {
+			d.metrics.DialSuccess++
+		}
+		d.mu.Unlock()
+
+		if err != nil {
+			d.logger.Debug("discovery dial failed", "peer", pi.ID, "rendezvous", ns, "error", err)
+		}
+	}
+}
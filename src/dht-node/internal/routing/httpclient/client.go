@@ -0,0 +1,141 @@
+// Package httpclient is a client for the Delegated Routing V1 HTTP API
+// (IPIP-337 / IPIP-417) exposed by a DHT VM's /routing/v1/ endpoints.
+//
+// It lets a DeCloud node agent query a remote DHT VM for content
+// providers, peer addresses, and IPNS records without joining libp2p
+// directly — useful for resource-constrained nodes that only want to
+// piggyback on another node's routing table.
+package httpclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Provider mirrors the Delegated Routing V1 provider record schema.
+type Provider struct {
+	Schema    string   `json:"Schema"`
+	ID        string   `json:"ID"`
+	Addrs     []string `json:"Addrs"`
+	Protocols []string `json:"Protocols"`
+}
+
+type providersResponse struct {
+	Providers []Provider `json:"Providers"`
+}
+
+// Client queries a remote DHT VM's delegated routing endpoint.
+type Client struct {
+	baseURL string
+	http    *http.Client
+}
+
+// New creates a Client for the delegated routing API at baseURL
+// (e.g. "http://10.0.0.5:5080").
+func New(baseURL string) *Client {
+	return &Client{
+		baseURL: baseURL,
+		http:    &http.Client{Timeout: 15 * time.Second},
+	}
+}
+
+// FindProviders queries GET /routing/v1/providers/{cid} for peers
+// advertising the given CID.
+func (c *Client) FindProviders(ctx context.Context, cidStr string) ([]Provider, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/routing/v1/providers/"+url.PathEscape(cidStr), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("find providers: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("find providers: unexpected status %d", resp.StatusCode)
+	}
+
+	var out providersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode providers response: %w", err)
+	}
+	return out.Providers, nil
+}
+
+// FindPeer queries GET /routing/v1/peers/{peer-id} for a peer's known
+// addresses.
+func (c *Client) FindPeer(ctx context.Context, peerID string) (*Provider, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/routing/v1/peers/"+url.PathEscape(peerID), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("find peer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("find peer: unexpected status %d", resp.StatusCode)
+	}
+
+	var out providersResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, fmt.Errorf("decode peer response: %w", err)
+	}
+	if len(out.Providers) == 0 {
+		return nil, fmt.Errorf("find peer: empty response")
+	}
+	return &out.Providers[0], nil
+}
+
+// GetIPNS fetches the raw IPNS record bytes for the given name via
+// GET /routing/v1/ipns/{name}.
+func (c *Client) GetIPNS(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/routing/v1/ipns/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("get ipns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get ipns: unexpected status %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// PutIPNS publishes a raw IPNS record via PUT /routing/v1/ipns/{name}.
+func (c *Client) PutIPNS(ctx context.Context, name string, record []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.baseURL+"/routing/v1/ipns/"+url.PathEscape(name), bytes.NewReader(record))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.ipfs.ipns-record")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("put ipns: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusAccepted {
+		return fmt.Errorf("put ipns: unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}